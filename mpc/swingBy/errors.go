@@ -0,0 +1,77 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// AbortError reports that a tss-lib round failed identifiably: it names the
+// round that failed and the numeric ids of whichever peers tss-lib's
+// protocol blamed, so callers get actionable feedback instead of a generic
+// error string or a silent deadlock.
+type AbortError struct {
+	Round    int
+	Culprits []uint16
+	Reason   string
+}
+
+func (e *AbortError) Error() string {
+	return fmt.Sprintf("round %d aborted, culprits %v: %s", e.Round, e.Culprits, e.Reason)
+}
+
+// newAbortError converts a tss-lib protocol error into an AbortError,
+// translating the tss-lib PartyIDs it blames back into numeric ids.
+func newAbortError(err *tss.Error) *AbortError {
+	culprits := make([]uint16, 0, len(err.Culprits()))
+	for _, culprit := range err.Culprits() {
+		culprits = append(culprits, numericID(culprit))
+	}
+
+	reason := err.Error()
+	if cause := err.Cause(); cause != nil {
+		reason = cause.Error()
+	}
+
+	return &AbortError{
+		Round:    tssErrorRound(err),
+		Culprits: culprits,
+		Reason:   reason,
+	}
+}
+
+// tssErrorRoundPattern matches the round number out of *tss.Error's Error()
+// string, which tss-lib formats as "task %s, party {%s}, round %d: %s".
+// *tss.Error stores its round internally and does not expose a Round()
+// getter, so this is the only way to recover it short of vendoring a patch.
+var tssErrorRoundPattern = regexp.MustCompile(`round (\d+)`)
+
+// tssErrorRound extracts the round number tss-lib attributed to err, or 0 if
+// its Error() string doesn't match the expected format.
+func tssErrorRound(err *tss.Error) int {
+	match := tssErrorRoundPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0
+	}
+	round, parseErr := strconv.Atoi(match[1])
+	if parseErr != nil {
+		return 0
+	}
+	return round
+}
+
+// newTransportAbortError reports that a Transport gave up on one or more
+// peers that never acknowledged a message. There is no tss-lib round
+// number to attach, since the failure happened before tss-lib ever saw the
+// message, so Round is left at its zero value.
+func newTransportAbortError(culprits []uint16, reason string) *AbortError {
+	return &AbortError{Culprits: culprits, Reason: reason}
+}
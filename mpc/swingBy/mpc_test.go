@@ -8,9 +8,8 @@ package swingBy
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"crypto/sha256"
 	"fmt"
-	"math/big"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -21,9 +20,14 @@ import (
 	"go.uber.org/zap"
 )
 
-func (parties parties) init(senders []Sender, threshold int) {
+func digest(msg []byte) []byte {
+	sum := sha256.Sum256(msg)
+	return sum[:]
+}
+
+func (parties parties) init(transports []Transport, threshold int) {
 	for i, p := range parties {
-		p.Init(parties.numericIDs(), threshold, senders[i])
+		p.Init(parties.numericIDs(), threshold, transports[i])
 	}
 }
 
@@ -33,7 +37,7 @@ func (parties parties) setShareData(shareData [][]byte) {
 	}
 }
 
-func (parties parties) sign(msg []byte) ([][]byte, error) {
+func (parties parties) sign(sessionID SessionID, msg []byte) ([][]byte, error) {
 	var lock sync.Mutex
 	var sigs [][]byte
 	var threadSafeError atomic.Value
@@ -44,7 +48,7 @@ func (parties parties) sign(msg []byte) ([][]byte, error) {
 	for _, p := range parties {
 		go func(p *party) {
 			defer wg.Done()
-			sig, err := p.Sign(context.Background(), msg)
+			sig, err := p.Sign(context.Background(), sessionID, msg)
 			if err != nil {
 				threadSafeError.Store(err.Error())
 				return
@@ -99,6 +103,49 @@ func (parties parties) keygen() ([][]byte, error) {
 	return shares, nil
 }
 
+func (parties parties) reshare(newThreshold int) ([][]byte, error) {
+	return parties.reshareTo(parties, parties.numericIDs(), newThreshold)
+}
+
+// reshareTo runs Reshare across committee onto newParties/newThreshold and
+// returns each committee member's resulting share in the same order as
+// committee. committee may differ from parties.numericIDs(): it can include
+// members being dropped from newParties (handing off their share and
+// discarding whatever Reshare returns them) as well as members being added
+// (who call Reshare with no prior share data of their own).
+func (parties parties) reshareTo(committee parties, newParties []uint16, newThreshold int) ([][]byte, error) {
+	var lock sync.Mutex
+	shares := make([][]byte, len(committee))
+	var threadSafeError atomic.Value
+
+	var wg sync.WaitGroup
+	wg.Add(len(committee))
+
+	for i, p := range committee {
+		go func(p *party, i int) {
+			defer wg.Done()
+			share, err := p.Reshare(context.Background(), newParties, newThreshold)
+			if err != nil {
+				threadSafeError.Store(err.Error())
+				return
+			}
+
+			lock.Lock()
+			shares[i] = share
+			lock.Unlock()
+		}(p, i)
+	}
+
+	wg.Wait()
+
+	err := threadSafeError.Load()
+	if err != nil {
+		return nil, fmt.Errorf(err.(string))
+	}
+
+	return shares, nil
+}
+
 func (parties parties) Mapping() map[string]*tss.PartyID {
 	partyIDMap := make(map[string]*tss.PartyID)
 	for _, id := range parties {
@@ -115,7 +162,7 @@ func (parties parties) Mapping() map[string]*tss.PartyID {
 	t.Logf("Created parties")
 
 	parties := parties{pA, pB, pC}
-	parties.init(senders(parties))
+	parties.init(transports(parties))
 
 	t.Logf("Running DKG")
 
@@ -124,7 +171,7 @@ func (parties parties) Mapping() map[string]*tss.PartyID {
 	assert.NoError(t, err)
 	t.Logf("DKG elapsed %s", time.Since(t1))
 
-	parties.init(senders(parties))
+	parties.init(transports(parties))
 
 	parties.setShareData(shares)
 	t.Logf("Signing")
@@ -149,31 +196,26 @@ func (parties parties) Mapping() map[string]*tss.PartyID {
 	assert.True(t, ecdsa.VerifyASN1(pk, digest(msgToSign), sigs[0]))
 }*/
 
-func senders(parties parties) []Sender {
-	var senders []Sender
-	for _, src := range parties {
-		src := src
-		sender := func(msgBytes []byte, broadcast bool, to uint16) {
-			messageSource := uint16(big.NewInt(0).SetBytes(src.id.Key).Uint64())
-			if broadcast {
-				for _, dst := range parties {
-					if dst.id == src.id {
-						continue
-					}
-					dst.OnMsg(msgBytes, messageSource, broadcast)
-				}
-			} else {
-				for _, dst := range parties {
-					if to != uint16(big.NewInt(0).SetBytes(dst.id.Key).Uint64()) {
-						continue
-					}
-					dst.OnMsg(msgBytes, messageSource, broadcast)
-				}
-			}
-		}
-		senders = append(senders, sender)
+// transports builds a Transport for every member of ps and wires them
+// together in-process, so each one's outbound Send reaches the others'
+// Deliver/Ack directly.
+func transports(ps parties, opts ...TransportOption) []Transport {
+	byID := make(map[uint16]Transport, len(ps))
+	cts := make([]*ChannelTransport, len(ps))
+	for i, p := range ps {
+		ct := NewChannelTransport(numericID(p.id), p.OnMsg, opts...)
+		cts[i] = ct
+		byID[numericID(p.id)] = ct
+	}
+	for _, ct := range cts {
+		ct.SetPeers(byID)
 	}
-	return senders
+
+	out := make([]Transport, len(ps))
+	for i, ct := range cts {
+		out[i] = ct
+	}
+	return out
 }
 
 func logger(id string, testName string) Logger {
@@ -211,7 +253,7 @@ func logger(id string, testName string) Logger {
 
 	parties := parties{pA, pB, pC, pD, pE, pF, pG, pH, pI, pJ, pK, pL, pM, pN, pO, pP, pQ, pR, pS, pT}
 	//parties := parties{pA, pB, pC, pD, pE, pF, pG, pH, pI}
-	parties.init(senders(parties), threshold)
+	parties.init(transports(parties), threshold)
 
 	t.Logf("Running DKG")
 
@@ -220,7 +262,7 @@ func logger(id string, testName string) Logger {
 	assert.NoError(t, err)
 	t.Logf("DKG elapsed %s", time.Since(t1))
 
-	parties.init(senders(parties), threshold)
+	parties.init(transports(parties), threshold)
 
 	parties.setShareData(shares)
 	t.Logf("Signing")
@@ -245,42 +287,96 @@ func logger(id string, testName string) Logger {
 	assert.True(t, ecdsa.VerifyASN1(pk, digest(msgToSign), sigs[0]))
 }*/
 
-func TestBenchmarkTss(t *testing.T) {
-	allParties := []*party{
-		NewParty(1, logger("pA", t.Name())),
-		NewParty(2, logger("pB", t.Name())),
-		NewParty(3, logger("pC", t.Name())),
-		NewParty(4, logger("pD", t.Name())),
-		NewParty(5, logger("pE", t.Name())),
-		NewParty(6, logger("pF", t.Name())),
-		NewParty(7, logger("pG", t.Name())),
-		NewParty(8, logger("pH", t.Name())),
-		NewParty(9, logger("pI", t.Name())),
-		NewParty(10, logger("pJ", t.Name())),
-		NewParty(11, logger("pK", t.Name())),
-		NewParty(12, logger("pL", t.Name())),
-		NewParty(13, logger("pM", t.Name())),
-		NewParty(14, logger("pN", t.Name())),
-		NewParty(15, logger("pO", t.Name())),
-		NewParty(16, logger("pP", t.Name())),
-		NewParty(17, logger("pQ", t.Name())),
-		NewParty(18, logger("pR", t.Name())),
-		NewParty(19, logger("pS", t.Name())),
-		NewParty(20, logger("pT", t.Name())),
+// benchmarkPreParamsCache times KeyGen for a fresh committee twice: once
+// with an empty PreParamsStore (paying the full pre-params generation cost)
+// and once with that same, now-populated store (reusing cached pre-params).
+func benchmarkPreParamsCache(t *testing.T, threshold, numParties int) (cold, warm time.Duration) {
+	store := NewInMemoryPreParamsStore()
+
+	newParties := func() parties {
+		ps := make(parties, numParties)
+		for i := 0; i < numParties; i++ {
+			ps[i] = NewParty(uint16(i+1), logger(fmt.Sprintf("pp%d", i+1), t.Name()),
+				WithScheme(SchemeECDSASecp256k1), WithPreParamsStore(store))
+		}
+		return ps
 	}
 
+	cp := newParties()
+	cp.init(transports(cp), threshold)
+	start := time.Now()
+	_, err := cp.keygen()
+	assert.NoError(t, err)
+	cold = time.Since(start)
+
+	wp := newParties()
+	wp.init(transports(wp), threshold)
+	start = time.Now()
+	_, err = wp.keygen()
+	assert.NoError(t, err)
+	warm = time.Since(start)
+
+	return cold, warm
+}
+
+// TestPregenPreParams checks that PregenPreParams actually populates a
+// PreParamsStore ahead of time, and that KeyGen (via loadOrGeneratePreParams)
+// reuses what it pre-generated instead of generating fresh pre-params.
+func TestPregenPreParams(t *testing.T) {
+	const numParties = 3
+
+	store := NewInMemoryPreParamsStore()
+	err := PregenPreParams(context.Background(), store, numParties)
+	assert.NoError(t, err)
+
+	for id := uint16(1); id <= numParties; id++ {
+		_, err := store.Load(id)
+		assert.NoError(t, err, "PregenPreParams should have populated party %d's pre-params ahead of time", id)
+	}
+
+	p := NewParty(1, logger("pA", t.Name()), WithPreParamsStore(store))
+	pregenerated, err := store.Load(1)
+	assert.NoError(t, err)
+
+	loaded, err := p.loadOrGeneratePreParams()
+	assert.NoError(t, err)
+	assert.Equal(t, pregenerated, loaded, "KeyGen should reuse the pre-generated pre-params rather than generating fresh ones")
+}
+
+func newBenchmarkParties(t *testing.T, scheme Scheme) []*party {
+	names := []string{"pA", "pB", "pC", "pD", "pE", "pF", "pG", "pH", "pI", "pJ",
+		"pK", "pL", "pM", "pN", "pO", "pP", "pQ", "pR", "pS", "pT"}
+
+	allParties := make([]*party, len(names))
+	for i, name := range names {
+		allParties[i] = NewParty(uint16(i+1), logger(name, t.Name()), WithScheme(scheme))
+	}
+	return allParties
+}
+
+func TestBenchmarkTss(t *testing.T) {
 	benchmarks := []struct {
+		scheme     Scheme
 		threshold  int
 		numParties int
 	}{
-		{2, 3}, /*, {2, 4}, {3, 4}, {2, 5}, {3, 5}, {4, 5},
-		{2, 6}, {3, 6}, {4, 6}, {5, 6}, {2, 7}, {14, 20},*/
+		{SchemeECDSASecp256k1, 2, 3},
+		{SchemeEDDSAEd25519, 2, 3},
+		/*, {SchemeECDSASecp256k1, 2, 4}, {SchemeECDSASecp256k1, 3, 4}, {SchemeECDSASecp256k1, 2, 5}, {SchemeECDSASecp256k1, 3, 5}, {SchemeECDSASecp256k1, 4, 5},
+		{SchemeECDSASecp256k1, 2, 6}, {SchemeECDSASecp256k1, 3, 6}, {SchemeECDSASecp256k1, 4, 6}, {SchemeECDSASecp256k1, 5, 6}, {SchemeECDSASecp256k1, 2, 7}, {SchemeECDSASecp256k1, 14, 20},*/
 	}
 
 	numRuns := 1
 
 	for _, bm := range benchmarks {
-		t.Run(fmt.Sprintf("Threshold:%d/Parties:%d", bm.threshold, bm.numParties), func(t *testing.T) {
+		t.Run(fmt.Sprintf("Scheme:%s/Threshold:%d/Parties:%d", bm.scheme, bm.threshold, bm.numParties), func(t *testing.T) {
+			if bm.scheme == SchemeECDSASecp256k1 {
+				coldDKGTime, warmDKGTime := benchmarkPreParamsCache(t, bm.threshold, bm.numParties)
+				t.Logf("Pre-params cache: cold DKG %v, warm DKG %v", coldDKGTime, warmDKGTime)
+			}
+
+			allParties := newBenchmarkParties(t, bm.scheme)
+
 			var totalDKGTime time.Duration
 			var totalSigningTime time.Duration
 
@@ -288,7 +384,7 @@ func TestBenchmarkTss(t *testing.T) {
 				parties := parties(allParties[:bm.numParties])
 				threshold := bm.threshold
 
-				parties.init(senders(parties), threshold)
+				parties.init(transports(parties), threshold)
 
 				// DKG
 				t1 := time.Now()
@@ -297,13 +393,37 @@ func TestBenchmarkTss(t *testing.T) {
 				dkgTime := time.Since(t1)
 				totalDKGTime += dkgTime
 
-				parties.init(senders(parties), threshold)
+				parties.init(transports(parties), threshold)
 				parties.setShareData(shares)
 
+				// Reshare over the same committee, keeping the same public key. The
+				// threshold is bumped only while it stays below numParties-1, so
+				// at threshold 2 of 3 parties it is left unchanged. Resharing is only wired up for SchemeECDSASecp256k1 so far.
+				if bm.scheme == SchemeECDSASecp256k1 {
+					pubKeyBeforeReshare, err := parties[0].TPubKey()
+					assert.NoError(t, err)
+
+					newThreshold := threshold
+					if newThreshold < bm.numParties-1 {
+						newThreshold++
+					}
+					reshared, err := parties.reshare(newThreshold)
+					assert.NoError(t, err)
+
+					parties.init(transports(parties), newThreshold)
+					parties.setShareData(reshared)
+
+					pubKeyAfterReshare, err := parties[0].TPubKey()
+					assert.NoError(t, err)
+					assert.Equal(t, pubKeyBeforeReshare, pubKeyAfterReshare)
+				}
+
 				// Signing
 				msgToSign := []byte("bla bla")
+				sessionID, err := NewSessionID()
+				assert.NoError(t, err)
 				t1 = time.Now()
-				sigs, err := parties.sign(digest(msgToSign))
+				sigs, err := parties.sign(sessionID, digest(msgToSign))
 				assert.NoError(t, err)
 				signingTime := time.Since(t1)
 				totalSigningTime += signingTime
@@ -316,18 +436,314 @@ func TestBenchmarkTss(t *testing.T) {
 					}
 					assert.Len(t, sigSet, 1)
 
-					pk, err := parties[0].TPubKey()
+					ok, err := parties[0].VerifySig(digest(msgToSign), sigs[0])
 					assert.NoError(t, err)
-					assert.True(t, ecdsa.VerifyASN1(pk, digest(msgToSign), sigs[0]))
+					assert.True(t, ok)
 				}
 			}
 
 			meanDKGTime := totalDKGTime / time.Duration(numRuns)
 			meanSigningTime := totalSigningTime / time.Duration(numRuns)
 
-			t.Logf("Parties: %d, Threshold: %d", bm.numParties, bm.threshold)
+			t.Logf("Scheme: %s, Parties: %d, Threshold: %d", bm.scheme, bm.numParties, bm.threshold)
 			t.Logf("Mean DKG time: %v", meanDKGTime)
 			t.Logf("Mean Signing time: %v", meanSigningTime)
 		})
 	}
 }
+
+// TestReshareMembershipChange covers the scenario TestBenchmarkTss doesn't:
+// Reshare changing committee membership rather than just bumping the
+// threshold over the same committee. A party is dropped and a brand-new
+// party (one that never ran KeyGen) joins in its place, and the resulting
+// shares are checked to still produce valid signatures under the original
+// public key.
+func TestReshareMembershipChange(t *testing.T) {
+	threshold := 2
+	allParties := newBenchmarkParties(t, SchemeECDSASecp256k1)
+	oldParties := parties(allParties[:3]) // p1, p2, p3
+	joiningParty := allParties[3]         // p4, never shared in the old committee
+
+	oldParties.init(transports(oldParties), threshold)
+	shares, err := oldParties.keygen()
+	assert.NoError(t, err)
+	oldParties.setShareData(shares)
+
+	pubKeyBeforeReshare, err := oldParties[0].TPubKey()
+	assert.NoError(t, err)
+
+	// p3 is dropped and p4 joins in its place; p1 and p2 stay. Every old
+	// member (including the one being dropped) and every new member
+	// (including the one joining) takes part in the reshare round.
+	union := append(append(parties{}, oldParties...), joiningParty)
+	oldCommitteeIDs := oldParties.numericIDs()
+	ts := transports(union)
+	for i, p := range union {
+		p.Init(oldCommitteeIDs, threshold, ts[i])
+	}
+
+	newCommittee := parties{oldParties[0], oldParties[1], joiningParty}
+	reshared, err := union.reshareTo(union, newCommittee.numericIDs(), threshold)
+	assert.NoError(t, err)
+
+	shareByID := make(map[uint16][]byte, len(union))
+	for i, p := range union {
+		shareByID[numericID(p.id)] = reshared[i]
+	}
+
+	newCommittee.init(transports(newCommittee), threshold)
+	for _, p := range newCommittee {
+		p.SetShareData(shareByID[numericID(p.id)])
+	}
+
+	pubKeyAfterReshare, err := newCommittee[0].TPubKey()
+	assert.NoError(t, err)
+	assert.Equal(t, pubKeyBeforeReshare, pubKeyAfterReshare)
+
+	msgToSign := []byte("bla bla")
+	sessionID, err := NewSessionID()
+	assert.NoError(t, err)
+	sigs, err := newCommittee.sign(sessionID, digest(msgToSign))
+	assert.NoError(t, err)
+
+	ok, err := newCommittee[0].VerifySig(digest(msgToSign), sigs[0])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+// TestReshareThresholdBump covers the other scenario TestBenchmarkTss's
+// reshare block does not reach at its configured sizes: a genuine threshold
+// bump. At
+// (threshold 2, 3 parties) the committee is already as small as threshold+1
+// allows, so bumping would exceed the committee size and there's nothing to
+// test; this uses a 5-party committee so the threshold can go from 2 to 4
+// while the committee itself stays fixed, which tss-lib's
+// NewReSharingParameters treats asymmetrically from the old/new party count.
+func TestReshareThresholdBump(t *testing.T) {
+	oldThreshold := 2
+	newThreshold := 4
+	allParties := newBenchmarkParties(t, SchemeECDSASecp256k1)
+	parties := parties(allParties[:5])
+
+	parties.init(transports(parties), oldThreshold)
+	shares, err := parties.keygen()
+	assert.NoError(t, err)
+	parties.setShareData(shares)
+
+	pubKeyBeforeReshare, err := parties[0].TPubKey()
+	assert.NoError(t, err)
+
+	reshared, err := parties.reshare(newThreshold)
+	assert.NoError(t, err)
+
+	parties.init(transports(parties), newThreshold)
+	parties.setShareData(reshared)
+
+	pubKeyAfterReshare, err := parties[0].TPubKey()
+	assert.NoError(t, err)
+	assert.Equal(t, pubKeyBeforeReshare, pubKeyAfterReshare)
+
+	msgToSign := []byte("bla bla")
+	sessionID, err := NewSessionID()
+	assert.NoError(t, err)
+	sigs, err := parties.sign(sessionID, digest(msgToSign))
+	assert.NoError(t, err)
+
+	ok, err := parties[0].VerifySig(digest(msgToSign), sigs[0])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestConcurrentSigning(t *testing.T) {
+	threshold := 2
+	allParties := newBenchmarkParties(t, SchemeECDSASecp256k1)
+	parties := parties(allParties[:3])
+
+	parties.init(transports(parties), threshold)
+
+	shares, err := parties.keygen()
+	assert.NoError(t, err)
+
+	parties.init(transports(parties), threshold)
+	parties.setShareData(shares)
+
+	const numConcurrentSigs = 5
+
+	var wg sync.WaitGroup
+	wg.Add(numConcurrentSigs)
+
+	sigsBySession := make([][]byte, numConcurrentSigs)
+	msgsBySession := make([][]byte, numConcurrentSigs)
+	errs := make([]error, numConcurrentSigs)
+
+	for i := 0; i < numConcurrentSigs; i++ {
+		sessionID, err := NewSessionID()
+		assert.NoError(t, err)
+		msg := digest([]byte(fmt.Sprintf("message #%d", i)))
+		msgsBySession[i] = msg
+
+		go func(i int, sessionID SessionID, msg []byte) {
+			defer wg.Done()
+			sigs, err := parties.sign(sessionID, msg)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			sigsBySession[i] = sigs[0]
+		}(i, sessionID, msg)
+	}
+
+	wg.Wait()
+
+	seen := make(map[string]struct{})
+	for i := 0; i < numConcurrentSigs; i++ {
+		assert.NoError(t, errs[i])
+
+		ok, err := parties[0].VerifySig(msgsBySession[i], sigsBySession[i])
+		assert.NoError(t, err)
+		assert.True(t, ok)
+
+		seen[string(sigsBySession[i])] = struct{}{}
+	}
+	assert.Len(t, seen, numConcurrentSigs, "each concurrent session should produce a distinct signature")
+}
+
+// corruptingTransport wraps a Transport so that every message it sends is
+// corrupted before delivery, simulating a misbehaving peer.
+type corruptingTransport struct {
+	Transport
+}
+
+func (t *corruptingTransport) Send(ctx context.Context, sessionID SessionID, msgBytes []byte, to uint16, broadcast bool) error {
+	if len(msgBytes) > 0 {
+		corrupted := make([]byte, len(msgBytes))
+		copy(corrupted, msgBytes)
+		corrupted[len(corrupted)-1] ^= 0xFF
+		msgBytes = corrupted
+	}
+	return t.Transport.Send(ctx, sessionID, msgBytes, to, broadcast)
+}
+
+// corruptingTransports wraps transports so that every outbound message from
+// the party identified by badID is corrupted before delivery, simulating a
+// misbehaving peer.
+func corruptingTransports(parties parties, badID uint16) []Transport {
+	base := transports(parties)
+	wrapped := make([]Transport, len(base))
+
+	for i, p := range parties {
+		if numericID(p.id) == badID {
+			wrapped[i] = &corruptingTransport{Transport: base[i]}
+		} else {
+			wrapped[i] = base[i]
+		}
+	}
+
+	return wrapped
+}
+
+// droppingTransport drops the first message delivered to it, simulating a
+// single lost network packet.
+type droppingTransport struct {
+	Transport
+	dropped int32
+}
+
+func (t *droppingTransport) Deliver(sessionID SessionID, msgID string, msgBytes []byte, from uint16, broadcast bool) {
+	if atomic.CompareAndSwapInt32(&t.dropped, 0, 1) {
+		return
+	}
+	t.Transport.Deliver(sessionID, msgID, msgBytes, from, broadcast)
+}
+
+// dropFirstDelivery wraps ts[i] in a droppingTransport and rewires every
+// transport's peer set to use the wrapper, so that peers' deliveries to
+// party i actually pass through it.
+func dropFirstDelivery(ps parties, ts []Transport, i int) {
+	base := append([]Transport(nil), ts...)
+	ts[i] = &droppingTransport{Transport: ts[i]}
+
+	peers := make(map[uint16]Transport, len(ps))
+	for j, p := range ps {
+		peers[numericID(p.id)] = ts[j]
+	}
+	for _, tr := range base {
+		tr.(*ChannelTransport).SetPeers(peers)
+	}
+}
+
+func TestAbortError(t *testing.T) {
+	threshold := 2
+	allParties := newBenchmarkParties(t, SchemeECDSASecp256k1)
+	parties := parties(allParties[:3])
+
+	parties.init(transports(parties), threshold)
+	shares, err := parties.keygen()
+	assert.NoError(t, err)
+
+	badPartyID := numericID(parties[0].id)
+
+	parties.init(corruptingTransports(parties, badPartyID), threshold)
+	parties.setShareData(shares)
+
+	sessionID, err := NewSessionID()
+	assert.NoError(t, err)
+
+	errs := make([]error, len(parties))
+	var wg sync.WaitGroup
+	wg.Add(len(parties))
+
+	for i, p := range parties {
+		go func(i int, p *party) {
+			defer wg.Done()
+			_, err := p.Sign(context.Background(), sessionID, digest([]byte("bla bla")))
+			errs[i] = err
+		}(i, p)
+	}
+	wg.Wait()
+
+	var abortErr *AbortError
+	for _, err := range errs {
+		if ae, ok := err.(*AbortError); ok {
+			abortErr = ae
+			break
+		}
+	}
+
+	if assert.NotNil(t, abortErr, "expected an AbortError naming the corrupting party") {
+		assert.Contains(t, abortErr.Culprits, badPartyID)
+		assert.NotZero(t, abortErr.Round, "expected the round tss-lib blamed to be parsed out of its error")
+	}
+}
+
+// TestTransportRetryAfterDroppedMessage drops the first message any one
+// transport receives and checks that Transport.Send's retry still gets the
+// protocol to completion, instead of the round hanging or erroring out.
+func TestTransportRetryAfterDroppedMessage(t *testing.T) {
+	threshold := 2
+	allParties := newBenchmarkParties(t, SchemeECDSASecp256k1)
+	parties := parties(allParties[:3])
+
+	ts := transports(parties, WithTransportDeadline(50*time.Millisecond))
+	dropFirstDelivery(parties, ts, 0)
+	parties.init(ts, threshold)
+
+	shares, err := parties.keygen()
+	assert.NoError(t, err)
+	assert.Len(t, shares, len(parties))
+
+	ts = transports(parties, WithTransportDeadline(50*time.Millisecond))
+	dropFirstDelivery(parties, ts, 1)
+	parties.init(ts, threshold)
+	parties.setShareData(shares)
+
+	sessionID, err := NewSessionID()
+	assert.NoError(t, err)
+
+	sigs, err := parties.sign(sessionID, digest([]byte("bla bla")))
+	assert.NoError(t, err)
+
+	ok, err := parties[0].VerifySig(digest([]byte("bla bla")), sigs[0])
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
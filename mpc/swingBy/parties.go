@@ -0,0 +1,21 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+// parties is a committee of local party handles, as used by tests to drive
+// a full protocol run across every member at once.
+type parties []*party
+
+// numericIDs returns the numeric ids of every member of the committee, in
+// the same order as parties itself.
+func (parties parties) numericIDs() []uint16 {
+	ids := make([]uint16, len(parties))
+	for i, p := range parties {
+		ids[i] = numericID(p.id)
+	}
+	return ids
+}
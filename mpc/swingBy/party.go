@@ -0,0 +1,633 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/binance-chain/tss-lib/common"
+	ecdsaKeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+	"github.com/binance-chain/tss-lib/ecdsa/resharing"
+	ecdsaSigning "github.com/binance-chain/tss-lib/ecdsa/signing"
+	eddsaKeygen "github.com/binance-chain/tss-lib/eddsa/keygen"
+	eddsaSigning "github.com/binance-chain/tss-lib/eddsa/signing"
+	"github.com/binance-chain/tss-lib/tss"
+)
+
+// Logger is the subset of *zap.SugaredLogger a party needs.
+type Logger interface {
+	Debugf(template string, args ...interface{})
+	Infof(template string, args ...interface{})
+	Warnf(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+}
+
+// runningParty pairs a live tss-lib protocol instance with the channel its
+// driving goroutine uses to report an async *tss.Error from Start, so that
+// OnMsg (which runs on a different goroutine) can also funnel errors it
+// observes from UpdateFromBytes back to whoever is waiting on the result.
+type runningParty struct {
+	tssParty tss.Party
+	errCh    chan *tss.Error
+}
+
+// party wraps a single tss-lib committee member and drives whichever
+// protocol (keygen, signing or resharing) is currently in flight for it.
+type party struct {
+	id     *tss.PartyID
+	logger Logger
+	scheme Scheme
+
+	lock           sync.Mutex
+	peerIDs        tss.SortedPartyIDs
+	threshold      int
+	transport      Transport
+	ecdsaShareData *ecdsaKeygen.LocalPartySaveData
+	eddsaShareData *eddsaKeygen.LocalPartySaveData
+	preParamsStore PreParamsStore
+
+	// resharePeerIDs is set for the duration of an in-flight Reshare to the
+	// union of the old and new committees, since resharing messages flow
+	// both ways between members that peerIDs alone (the old committee)
+	// would not resolve a sender against, e.g. a joining party that was
+	// never part of peerIDs.
+	resharePeerIDs tss.SortedPartyIDs
+
+	running *runningParty
+
+	signSessionsLock sync.Mutex
+	signSessions     map[SessionID]*runningParty
+}
+
+// NewParty creates a party identified by the given numeric id. The id is
+// also used as the party's tss-lib key, so it must be unique and non-zero
+// within a committee. It defaults to SchemeECDSASecp256k1; pass WithScheme
+// to run a different subprotocol.
+func NewParty(id uint16, logger Logger, opts ...Option) *party {
+	p := &party{
+		id:     partyIDFromNumeric(id),
+		logger: logger,
+		scheme: SchemeECDSASecp256k1,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Init configures the committee this party participates in: the numeric ids
+// of all members (itself included), the signing/keygen threshold, and the
+// Transport used to exchange protocol messages with peers.
+func (p *party) Init(parties []uint16, threshold int, transport Transport) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.peerIDs = sortedPartyIDs(parties)
+	p.threshold = threshold
+	p.transport = transport
+}
+
+// SetShareData installs a previously generated (or reshared) key share.
+func (p *party) SetShareData(shareData []byte) {
+	switch p.scheme {
+	case SchemeEDDSAEd25519:
+		var save eddsaKeygen.LocalPartySaveData
+		if err := json.Unmarshal(shareData, &save); err != nil {
+			p.logger.Errorf("failed unmarshaling share data: %v", err)
+			return
+		}
+		p.lock.Lock()
+		p.eddsaShareData = &save
+		p.lock.Unlock()
+	default:
+		var save ecdsaKeygen.LocalPartySaveData
+		if err := json.Unmarshal(shareData, &save); err != nil {
+			p.logger.Errorf("failed unmarshaling share data: %v", err)
+			return
+		}
+		p.lock.Lock()
+		p.ecdsaShareData = &save
+		p.lock.Unlock()
+	}
+}
+
+// KeyGen runs the tss-lib distributed key generation protocol and returns
+// this party's serialized share of the resulting key.
+func (p *party) KeyGen(ctx context.Context) ([]byte, error) {
+	p.lock.Lock()
+	peerIDs := p.peerIDs
+	threshold := p.threshold
+	p.lock.Unlock()
+
+	if p.scheme == SchemeEDDSAEd25519 {
+		return p.keygenEdDSA(ctx, peerIDs, threshold)
+	}
+	return p.keygenECDSA(ctx, peerIDs, threshold)
+}
+
+func (p *party) keygenECDSA(ctx context.Context, peerIDs tss.SortedPartyIDs, threshold int) ([]byte, error) {
+	preParams, err := p.loadOrGeneratePreParams()
+	if err != nil {
+		return nil, err
+	}
+
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(peerIDs), p.id, len(peerIDs), threshold)
+
+	out := make(chan tss.Message, len(peerIDs))
+	end := make(chan ecdsaKeygen.LocalPartySaveData, 1)
+
+	lp := ecdsaKeygen.NewLocalParty(params, out, end, *preParams)
+	rp := p.setTSSParty(lp)
+	defer p.setTSSParty(nil)
+
+	go func() {
+		if err := lp.Start(); err != nil {
+			rp.errCh <- err
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-rp.errCh:
+			return nil, newAbortError(err)
+		case msg := <-out:
+			if err := p.broadcast(ctx, SessionID{}, msg); err != nil {
+				return nil, err
+			}
+		case save := <-end:
+			p.lock.Lock()
+			p.ecdsaShareData = &save
+			p.lock.Unlock()
+			return json.Marshal(save)
+		}
+	}
+}
+
+// loadOrGeneratePreParams returns this party's cached pre-params from its
+// PreParamsStore if one is configured and has an entry, generating and
+// caching a fresh set otherwise.
+func (p *party) loadOrGeneratePreParams() (*ecdsaKeygen.LocalPreParams, error) {
+	id := numericID(p.id)
+
+	if p.preParamsStore != nil {
+		if params, err := p.preParamsStore.Load(id); err == nil {
+			return params, nil
+		}
+	}
+
+	params, err := ecdsaKeygen.GeneratePreParams(time.Minute)
+	if err != nil {
+		return nil, fmt.Errorf("generating pre-params: %w", err)
+	}
+
+	if p.preParamsStore != nil {
+		if err := p.preParamsStore.Save(id, params); err != nil {
+			p.logger.Warnf("failed caching pre-params: %v", err)
+		}
+	}
+
+	return params, nil
+}
+
+func (p *party) keygenEdDSA(ctx context.Context, peerIDs tss.SortedPartyIDs, threshold int) ([]byte, error) {
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(peerIDs), p.id, len(peerIDs), threshold)
+
+	out := make(chan tss.Message, len(peerIDs))
+	end := make(chan eddsaKeygen.LocalPartySaveData, 1)
+
+	lp := eddsaKeygen.NewLocalParty(params, out, end)
+	rp := p.setTSSParty(lp)
+	defer p.setTSSParty(nil)
+
+	go func() {
+		if err := lp.Start(); err != nil {
+			rp.errCh <- err
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-rp.errCh:
+			return nil, newAbortError(err)
+		case msg := <-out:
+			if err := p.broadcast(ctx, SessionID{}, msg); err != nil {
+				return nil, err
+			}
+		case save := <-end:
+			p.lock.Lock()
+			p.eddsaShareData = &save
+			p.lock.Unlock()
+			return json.Marshal(save)
+		}
+	}
+}
+
+// Sign runs the tss-lib threshold signing protocol over msg under sessionID
+// and returns the resulting signature, in whatever encoding the party's
+// Scheme produces (ASN.1 DER for SchemeECDSASecp256k1, raw R||S for
+// SchemeEDDSAEd25519). Distinct, concurrent sessionIDs may be signed over
+// the same committee in parallel.
+func (p *party) Sign(ctx context.Context, sessionID SessionID, msg []byte) ([]byte, error) {
+	p.lock.Lock()
+	peerIDs := p.peerIDs
+	threshold := p.threshold
+	ecdsaShareData := p.ecdsaShareData
+	eddsaShareData := p.eddsaShareData
+	p.lock.Unlock()
+
+	if p.scheme == SchemeEDDSAEd25519 {
+		if eddsaShareData == nil {
+			return nil, fmt.Errorf("no key share set for party %s", p.id.Id)
+		}
+		return p.signEdDSA(ctx, sessionID, peerIDs, threshold, eddsaShareData, msg)
+	}
+
+	if ecdsaShareData == nil {
+		return nil, fmt.Errorf("no key share set for party %s", p.id.Id)
+	}
+	return p.signECDSA(ctx, sessionID, peerIDs, threshold, ecdsaShareData, msg)
+}
+
+func (p *party) signECDSA(ctx context.Context, sessionID SessionID, peerIDs tss.SortedPartyIDs, threshold int, shareData *ecdsaKeygen.LocalPartySaveData, msg []byte) ([]byte, error) {
+	params := tss.NewParameters(tss.S256(), tss.NewPeerContext(peerIDs), p.id, len(peerIDs), threshold)
+
+	out := make(chan tss.Message, len(peerIDs))
+	end := make(chan common.SignatureData, 1)
+
+	lp := ecdsaSigning.NewLocalParty(new(big.Int).SetBytes(msg), params, *shareData, out, end)
+	rp := p.setSignSession(sessionID, lp)
+	defer p.clearSignSession(sessionID)
+	defer p.closeTransportSession(sessionID)
+
+	go func() {
+		if err := lp.Start(); err != nil {
+			rp.errCh <- err
+		}
+	}()
+
+	return p.driveSignature(ctx, sessionID, rp, out, end)
+}
+
+func (p *party) signEdDSA(ctx context.Context, sessionID SessionID, peerIDs tss.SortedPartyIDs, threshold int, shareData *eddsaKeygen.LocalPartySaveData, msg []byte) ([]byte, error) {
+	params := tss.NewParameters(tss.Edwards(), tss.NewPeerContext(peerIDs), p.id, len(peerIDs), threshold)
+
+	out := make(chan tss.Message, len(peerIDs))
+	end := make(chan common.SignatureData, 1)
+
+	lp := eddsaSigning.NewLocalParty(new(big.Int).SetBytes(msg), params, *shareData, out, end)
+	rp := p.setSignSession(sessionID, lp)
+	defer p.clearSignSession(sessionID)
+	defer p.closeTransportSession(sessionID)
+
+	go func() {
+		if err := lp.Start(); err != nil {
+			rp.errCh <- err
+		}
+	}()
+
+	return p.driveSignature(ctx, sessionID, rp, out, end)
+}
+
+func (p *party) driveSignature(ctx context.Context, sessionID SessionID, rp *runningParty, out chan tss.Message, end chan common.SignatureData) ([]byte, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-rp.errCh:
+			return nil, newAbortError(err)
+		case msg := <-out:
+			if err := p.broadcast(ctx, sessionID, msg); err != nil {
+				return nil, err
+			}
+		case sig := <-end:
+			if p.scheme == SchemeECDSASecp256k1 {
+				return asn1.Marshal(struct{ R, S *big.Int }{
+					R: new(big.Int).SetBytes(sig.R),
+					S: new(big.Int).SetBytes(sig.S),
+				})
+			}
+			return sig.Signature, nil
+		}
+	}
+}
+
+// Reshare runs the tss-lib resharing protocol, redistributing this party's
+// key share to newParties under newThreshold while preserving the public
+// key reported by TPubKey/PublicKey. Parties being dropped from the
+// committee should still call Reshare with their current share so they can
+// hand it off; they may discard the result afterwards.
+//
+// Reshare currently only supports SchemeECDSASecp256k1; tss-lib does not
+// ship an eddsa resharing subprotocol.
+func (p *party) Reshare(ctx context.Context, newParties []uint16, newThreshold int) ([]byte, error) {
+	if p.scheme != SchemeECDSASecp256k1 {
+		return nil, fmt.Errorf("resharing is not supported for scheme %s", p.scheme)
+	}
+
+	p.lock.Lock()
+	oldPeerIDs := p.peerIDs
+	oldThreshold := p.threshold
+	shareData := p.ecdsaShareData
+	p.lock.Unlock()
+
+	newPeerIDs := sortedPartyIDs(newParties)
+
+	p.lock.Lock()
+	p.resharePeerIDs = unionPartyIDs(oldPeerIDs, newPeerIDs)
+	p.lock.Unlock()
+	defer func() {
+		p.lock.Lock()
+		p.resharePeerIDs = nil
+		p.lock.Unlock()
+	}()
+
+	oldCtx := tss.NewPeerContext(oldPeerIDs)
+	newCtx := tss.NewPeerContext(newPeerIDs)
+
+	params := tss.NewReSharingParameters(tss.S256(), oldCtx, newCtx, p.id,
+		len(oldPeerIDs), oldThreshold, len(newPeerIDs), newThreshold)
+
+	var save ecdsaKeygen.LocalPartySaveData
+	if shareData != nil {
+		save = *shareData
+	}
+
+	out := make(chan tss.Message, len(oldPeerIDs)+len(newPeerIDs))
+	end := make(chan ecdsaKeygen.LocalPartySaveData, 1)
+
+	lp := resharing.NewLocalParty(params, save, out, end)
+	rp := p.setTSSParty(lp)
+	defer p.setTSSParty(nil)
+
+	go func() {
+		if err := lp.Start(); err != nil {
+			rp.errCh <- err
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-rp.errCh:
+			return nil, newAbortError(err)
+		case msg := <-out:
+			if err := p.broadcast(ctx, SessionID{}, msg); err != nil {
+				return nil, err
+			}
+		case newSave := <-end:
+			p.lock.Lock()
+			p.peerIDs = newPeerIDs
+			p.threshold = newThreshold
+			p.ecdsaShareData = &newSave
+			p.lock.Unlock()
+			return json.Marshal(newSave)
+		}
+	}
+}
+
+// OnMsg feeds an inbound protocol message, received from the peer with the
+// given numeric id under sessionID, into whichever tss-lib protocol
+// instance is running that session (the keygen/resharing party for the
+// zero SessionID, or the matching signing party otherwise). An error
+// tss-lib attributes to this round is forwarded to the goroutine driving
+// that session, which surfaces it as an AbortError.
+func (p *party) OnMsg(sessionID SessionID, msgBytes []byte, from uint16, broadcast bool) {
+	p.lock.Lock()
+	sender := p.peerByNumericID(from)
+	p.lock.Unlock()
+
+	if sender == nil {
+		return
+	}
+
+	rp := p.runningPartyForSession(sessionID)
+	if rp == nil {
+		return
+	}
+
+	if _, err := rp.tssParty.UpdateFromBytes(msgBytes, sender, broadcast); err != nil {
+		p.logger.Warnf("failed updating party with message from %d: %v", from, err)
+		select {
+		case rp.errCh <- err:
+		default:
+		}
+	}
+}
+
+func (p *party) runningPartyForSession(sessionID SessionID) *runningParty {
+	if sessionID == (SessionID{}) {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		return p.running
+	}
+
+	p.signSessionsLock.Lock()
+	defer p.signSessionsLock.Unlock()
+	return p.signSessions[sessionID]
+}
+
+func (p *party) setSignSession(sessionID SessionID, tssParty tss.Party) *runningParty {
+	rp := &runningParty{tssParty: tssParty, errCh: make(chan *tss.Error, 1)}
+
+	p.signSessionsLock.Lock()
+	defer p.signSessionsLock.Unlock()
+	if p.signSessions == nil {
+		p.signSessions = make(map[SessionID]*runningParty)
+	}
+	p.signSessions[sessionID] = rp
+	return rp
+}
+
+func (p *party) clearSignSession(sessionID SessionID) {
+	p.signSessionsLock.Lock()
+	defer p.signSessionsLock.Unlock()
+	delete(p.signSessions, sessionID)
+}
+
+// closeTransportSession tells this party's Transport that sessionID is done,
+// so it can drop whatever per-session delivery state it is holding for it
+// rather than keeping it for the life of the Transport.
+func (p *party) closeTransportSession(sessionID SessionID) {
+	p.lock.Lock()
+	transport := p.transport
+	p.lock.Unlock()
+
+	if transport != nil {
+		transport.CloseSession(sessionID)
+	}
+}
+
+// TPubKey returns the ECDSA public key this party's share belongs to. It is
+// only valid for SchemeECDSASecp256k1 parties; use PublicKey for a
+// scheme-agnostic accessor.
+func (p *party) TPubKey() (*ecdsa.PublicKey, error) {
+	p.lock.Lock()
+	shareData := p.ecdsaShareData
+	p.lock.Unlock()
+
+	if shareData == nil {
+		return nil, fmt.Errorf("no key share set for party %s", p.id.Id)
+	}
+
+	pk := shareData.ECDSAPub
+	return &ecdsa.PublicKey{Curve: tss.S256(), X: pk.X(), Y: pk.Y()}, nil
+}
+
+// PublicKey returns the group public key this party's share belongs to, serialized
+// the way the party's Scheme natively encodes it: an uncompressed SEC1
+// point for SchemeECDSASecp256k1, or a 32-byte compressed point for
+// SchemeEDDSAEd25519.
+func (p *party) PublicKey() ([]byte, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch p.scheme {
+	case SchemeEDDSAEd25519:
+		if p.eddsaShareData == nil {
+			return nil, fmt.Errorf("no key share set for party %s", p.id.Id)
+		}
+		pk := p.eddsaShareData.EDDSAPub
+		return compressEdwardsPoint(pk.X(), pk.Y()), nil
+	default:
+		if p.ecdsaShareData == nil {
+			return nil, fmt.Errorf("no key share set for party %s", p.id.Id)
+		}
+		pk := p.ecdsaShareData.ECDSAPub
+		return elliptic.Marshal(tss.S256(), pk.X(), pk.Y()), nil
+	}
+}
+
+// VerifySig checks sig against msg using the group public key this party's
+// share belongs to, dispatching to the verification routine for the party's
+// Scheme.
+func (p *party) VerifySig(msg, sig []byte) (bool, error) {
+	switch p.scheme {
+	case SchemeEDDSAEd25519:
+		pubKey, err := p.PublicKey()
+		if err != nil {
+			return false, err
+		}
+		return ed25519.Verify(ed25519.PublicKey(pubKey), msg, sig), nil
+	default:
+		pubKey, err := p.TPubKey()
+		if err != nil {
+			return false, err
+		}
+		return ecdsa.VerifyASN1(pubKey, msg, sig), nil
+	}
+}
+
+// setTSSParty installs the single keygen/resharing protocol instance
+// currently running, returning its runningParty so the caller's driving
+// goroutine can report a *tss.Error on its errCh. Passing nil clears it.
+func (p *party) setTSSParty(tssParty tss.Party) *runningParty {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if tssParty == nil {
+		p.running = nil
+		return nil
+	}
+
+	p.running = &runningParty{tssParty: tssParty, errCh: make(chan *tss.Error, 1)}
+	return p.running
+}
+
+func (p *party) peerByNumericID(id uint16) *tss.PartyID {
+	for _, peerID := range p.peerIDs {
+		if numericID(peerID) == id {
+			return peerID
+		}
+	}
+	for _, peerID := range p.resharePeerIDs {
+		if numericID(peerID) == id {
+			return peerID
+		}
+	}
+	return nil
+}
+
+func (p *party) broadcast(ctx context.Context, sessionID SessionID, msg tss.Message) error {
+	msgBytes, _, err := msg.WireBytes()
+	if err != nil {
+		return fmt.Errorf("marshaling outgoing message: %w", err)
+	}
+
+	if msg.IsBroadcast() {
+		return p.transport.Send(ctx, sessionID, msgBytes, 0, true)
+	}
+
+	for _, to := range msg.GetTo() {
+		if err := p.transport.Send(ctx, sessionID, msgBytes, numericID(to), false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func partyIDFromNumeric(id uint16) *tss.PartyID {
+	key := new(big.Int).SetUint64(uint64(id))
+	return tss.NewPartyID(fmt.Sprintf("%d", id), "", key)
+}
+
+func sortedPartyIDs(ids []uint16) tss.SortedPartyIDs {
+	var unsorted tss.UnSortedPartyIDs
+	for _, id := range ids {
+		unsorted = append(unsorted, partyIDFromNumeric(id))
+	}
+	return tss.SortPartyIDs(unsorted)
+}
+
+func numericID(id *tss.PartyID) uint16 {
+	return uint16(new(big.Int).SetBytes(id.Key).Uint64())
+}
+
+// unionPartyIDs returns the sorted union of a and b, deduplicated by
+// numeric id.
+func unionPartyIDs(a, b tss.SortedPartyIDs) tss.SortedPartyIDs {
+	seen := make(map[uint16]struct{}, len(a)+len(b))
+	var unsorted tss.UnSortedPartyIDs
+	for _, peerID := range append(append(tss.SortedPartyIDs{}, a...), b...) {
+		id := numericID(peerID)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unsorted = append(unsorted, peerID)
+	}
+	return tss.SortPartyIDs(unsorted)
+}
+
+// compressEdwardsPoint encodes an edwards25519 point the way RFC 8032
+// encodes ed25519 public keys: the little-endian Y coordinate with the top
+// bit replaced by the sign of X.
+func compressEdwardsPoint(x, y *big.Int) []byte {
+	out := make([]byte, 32)
+	yBytes := y.Bytes()
+	copy(out[32-len(yBytes):], yBytes)
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	if x.Bit(0) == 1 {
+		out[31] |= 0x80
+	}
+	return out
+}
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	ecdsaKeygen "github.com/binance-chain/tss-lib/ecdsa/keygen"
+)
+
+// PreParamsStore caches the safe-prime material keygen.GeneratePreParams
+// produces, keyed by the numeric id of the party it was generated for, so
+// that KeyGen does not have to pay for regenerating it on every run.
+type PreParamsStore interface {
+	Load(partyID uint16) (*ecdsaKeygen.LocalPreParams, error)
+	Save(partyID uint16, params *ecdsaKeygen.LocalPreParams) error
+}
+
+// InMemoryPreParamsStore is a process-local PreParamsStore. It is safe for
+// concurrent use.
+type InMemoryPreParamsStore struct {
+	lock   sync.Mutex
+	params map[uint16]*ecdsaKeygen.LocalPreParams
+}
+
+// NewInMemoryPreParamsStore returns an empty InMemoryPreParamsStore.
+func NewInMemoryPreParamsStore() *InMemoryPreParamsStore {
+	return &InMemoryPreParamsStore{params: make(map[uint16]*ecdsaKeygen.LocalPreParams)}
+}
+
+func (s *InMemoryPreParamsStore) Load(partyID uint16) (*ecdsaKeygen.LocalPreParams, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	params, ok := s.params[partyID]
+	if !ok {
+		return nil, fmt.Errorf("no cached pre-params for party %d", partyID)
+	}
+	return params, nil
+}
+
+func (s *InMemoryPreParamsStore) Save(partyID uint16, params *ecdsaKeygen.LocalPreParams) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.params[partyID] = params
+	return nil
+}
+
+// FilePreParamsStore persists pre-params as one JSON file per party under a
+// base directory, so they survive across process restarts.
+type FilePreParamsStore struct {
+	dir string
+}
+
+// NewFilePreParamsStore returns a FilePreParamsStore rooted at dir. dir is
+// created on first Save if it does not already exist.
+func NewFilePreParamsStore(dir string) *FilePreParamsStore {
+	return &FilePreParamsStore{dir: dir}
+}
+
+func (s *FilePreParamsStore) path(partyID uint16) string {
+	return filepath.Join(s.dir, fmt.Sprintf("preparams-%d.json", partyID))
+}
+
+func (s *FilePreParamsStore) Load(partyID uint16) (*ecdsaKeygen.LocalPreParams, error) {
+	data, err := os.ReadFile(s.path(partyID))
+	if err != nil {
+		return nil, err
+	}
+
+	var params ecdsaKeygen.LocalPreParams
+	if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("unmarshaling cached pre-params for party %d: %w", partyID, err)
+	}
+	return &params, nil
+}
+
+func (s *FilePreParamsStore) Save(partyID uint16, params *ecdsaKeygen.LocalPreParams) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("marshaling pre-params for party %d: %w", partyID, err)
+	}
+
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return fmt.Errorf("creating pre-params directory: %w", err)
+	}
+	return os.WriteFile(s.path(partyID), data, 0o600)
+}
+
+// PregenPreParams fills store with freshly generated pre-params for parties
+// numbered 1..n, so that those parties' first KeyGen call is warm. It stops
+// early if ctx is cancelled.
+func PregenPreParams(ctx context.Context, store PreParamsStore, n int) error {
+	for id := 1; id <= n; id++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		params, err := ecdsaKeygen.GeneratePreParams(time.Minute)
+		if err != nil {
+			return fmt.Errorf("generating pre-params for party %d: %w", id, err)
+		}
+		if err := store.Save(uint16(id), params); err != nil {
+			return fmt.Errorf("saving pre-params for party %d: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+// Scheme selects the tss-lib subprotocol a party runs its keygen, signing
+// and resharing rounds under.
+type Scheme int
+
+const (
+	// SchemeECDSASecp256k1 runs the ecdsa/* tss-lib packages over the
+	// secp256k1 curve. This is the scheme parties used before Scheme
+	// existed, and remains the default.
+	SchemeECDSASecp256k1 Scheme = iota
+	// SchemeEDDSAEd25519 runs the eddsa/* tss-lib packages over edwards25519.
+	SchemeEDDSAEd25519
+)
+
+func (s Scheme) String() string {
+	switch s {
+	case SchemeECDSASecp256k1:
+		return "ecdsa-secp256k1"
+	case SchemeEDDSAEd25519:
+		return "eddsa-ed25519"
+	default:
+		return "unknown"
+	}
+}
+
+// Option configures optional NewParty behavior.
+type Option func(*party)
+
+// WithScheme selects the signature scheme a party runs. Parties default to
+// SchemeECDSASecp256k1 when no Option is given.
+func WithScheme(scheme Scheme) Option {
+	return func(p *party) {
+		p.scheme = scheme
+	}
+}
+
+// WithPreParamsStore makes KeyGen reuse pre-params cached in store (keyed by
+// the party's own numeric id) instead of generating fresh ones every run.
+// Only SchemeECDSASecp256k1 keygen uses pre-params.
+func WithPreParamsStore(store PreParamsStore) Option {
+	return func(p *party) {
+		p.preParamsStore = store
+	}
+}
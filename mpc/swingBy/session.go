@@ -0,0 +1,24 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+import "crypto/rand"
+
+// SessionID identifies one concurrent Sign run among a committee. Callers
+// use a distinct SessionID per in-flight signing so a party can multiplex
+// several tss-lib signing parties at once. The zero SessionID is reserved
+// for the single keygen/resharing round a party runs at a time.
+type SessionID [32]byte
+
+// NewSessionID generates a random, non-zero SessionID.
+func NewSessionID() (SessionID, error) {
+	var id SessionID
+	if _, err := rand.Read(id[:]); err != nil {
+		return SessionID{}, err
+	}
+	return id, nil
+}
@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package swingBy
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OnMsgFunc delivers an inbound protocol message to a party; it is
+// ordinarily a party's OnMsg method.
+type OnMsgFunc func(sessionID SessionID, msgBytes []byte, from uint16, broadcast bool)
+
+// Transport replaces the bare, fire-and-forget Sender a party used to be
+// handed at Init time. Instead of a single function call, a Transport owns
+// bounded per-peer delivery queues and a configurable per-round deadline:
+// point-to-point sends are retried until acknowledged, broadcasts are
+// redelivered from the origin to whichever peers have not yet acknowledged
+// (there is no peer-to-peer forwarding), and once retries are exhausted Send
+// reports the silent peers as an AbortError rather than leaving the round
+// hanging.
+type Transport interface {
+	// Send ships msgBytes to peer to, or to every other committee member
+	// when broadcast is true (to is then ignored). It blocks until every
+	// addressed peer has acknowledged receipt, ctx is done, or retries are
+	// exhausted.
+	Send(ctx context.Context, sessionID SessionID, msgBytes []byte, to uint16, broadcast bool) error
+
+	// Deliver hands this Transport an inbound message from peer, queuing
+	// it on a bounded channel rather than invoking OnMsg on the caller's
+	// own goroutine.
+	Deliver(sessionID SessionID, msgID string, msgBytes []byte, from uint16, broadcast bool)
+
+	// Ack acknowledges, back to the peer that sent it, receipt of the
+	// message identified by msgID.
+	Ack(sessionID SessionID, msgID string, from uint16)
+
+	// CloseSession releases whatever per-session delivery state this
+	// Transport is holding for sessionID (its per-peer inboxes and their
+	// draining goroutines), once sessionID has produced its result and
+	// will receive no further messages. Callers should invoke it when a
+	// signing session completes so a long-lived Transport does not
+	// accumulate state for every session it has ever seen.
+	CloseSession(sessionID SessionID)
+}
+
+const (
+	defaultTransportDeadline   = 5 * time.Second
+	defaultTransportMaxRetries = 3
+	defaultTransportInboxSize  = 32
+)
+
+// TransportOption configures optional ChannelTransport behavior.
+type TransportOption func(*ChannelTransport)
+
+// WithTransportDeadline overrides how long Send waits for an acknowledgement
+// before retrying (or, for a broadcast, redelivering to whichever peers
+// have not yet acknowledged).
+func WithTransportDeadline(d time.Duration) TransportOption {
+	return func(t *ChannelTransport) { t.deadline = d }
+}
+
+// WithTransportMaxRetries overrides how many redelivery attempts Send makes
+// before giving up on a silent peer.
+func WithTransportMaxRetries(n int) TransportOption {
+	return func(t *ChannelTransport) { t.maxRetries = n }
+}
+
+type inboundMessage struct {
+	sessionID SessionID
+	msgID     string
+	msgBytes  []byte
+	broadcast bool
+}
+
+type pendingSend struct {
+	lock sync.Mutex
+	need map[uint16]struct{}
+	done chan struct{}
+}
+
+// inboxKey identifies one (peer, session) pair's delivery queue. Keying on
+// sessionID as well as the sending peer keeps concurrent sessions (see
+// SessionID) from head-of-line blocking behind one another when they share
+// a peer: each gets its own channel and its own draining goroutine.
+type inboxKey struct {
+	from      uint16
+	sessionID SessionID
+}
+
+// inboxEntry is one (peer, session) pair's bounded delivery queue and the
+// draining goroutine reading it. done is closed by CloseSession to stop
+// that goroutine once the session is over, without closing ch itself (a
+// send racing the close would panic on a closed channel; ch is instead
+// just abandoned for the garbage collector once the entry is unreachable).
+type inboxEntry struct {
+	ch   chan inboundMessage
+	done chan struct{}
+}
+
+// ChannelTransport is an in-process Transport: Deliver and Ack calls are
+// ordinary Go method calls into the destination's ChannelTransport, made
+// either directly (as the in-memory test helpers do) or from whatever
+// wraps real network I/O around it.
+type ChannelTransport struct {
+	id    uint16
+	onMsg OnMsgFunc
+
+	deadline   time.Duration
+	maxRetries int
+	inboxSize  int
+
+	peersLock sync.RWMutex
+	peers     map[uint16]Transport
+
+	inboxesLock sync.Mutex
+	inboxes     map[inboxKey]*inboxEntry
+
+	pendingLock sync.Mutex
+	pending     map[string]*pendingSend
+}
+
+// NewChannelTransport returns a Transport for the committee member
+// identified by id, delivering inbound messages to onMsg. Call SetPeers
+// once every member's Transport has been constructed and before any
+// party.Init using it.
+func NewChannelTransport(id uint16, onMsg OnMsgFunc, opts ...TransportOption) *ChannelTransport {
+	t := &ChannelTransport{
+		id:         id,
+		onMsg:      onMsg,
+		deadline:   defaultTransportDeadline,
+		maxRetries: defaultTransportMaxRetries,
+		inboxSize:  defaultTransportInboxSize,
+		peers:      make(map[uint16]Transport),
+		inboxes:    make(map[inboxKey]*inboxEntry),
+		pending:    make(map[string]*pendingSend),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// SetPeers wires this Transport to every member of the committee,
+// including itself (which Send simply never addresses).
+func (t *ChannelTransport) SetPeers(peers map[uint16]Transport) {
+	t.peersLock.Lock()
+	defer t.peersLock.Unlock()
+	t.peers = peers
+}
+
+func (t *ChannelTransport) peer(id uint16) Transport {
+	t.peersLock.RLock()
+	defer t.peersLock.RUnlock()
+	return t.peers[id]
+}
+
+func (t *ChannelTransport) peerIDs() []uint16 {
+	t.peersLock.RLock()
+	defer t.peersLock.RUnlock()
+
+	ids := make([]uint16, 0, len(t.peers))
+	for id := range t.peers {
+		if id == t.id {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (t *ChannelTransport) Send(ctx context.Context, sessionID SessionID, msgBytes []byte, to uint16, broadcast bool) error {
+	targets := []uint16{to}
+	if broadcast {
+		targets = t.peerIDs()
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	msgID := newTransportMsgID()
+	need := make(map[uint16]struct{}, len(targets))
+	for _, id := range targets {
+		need[id] = struct{}{}
+	}
+
+	ps := &pendingSend{need: need, done: make(chan struct{})}
+	t.pendingLock.Lock()
+	t.pending[msgID] = ps
+	t.pendingLock.Unlock()
+	defer func() {
+		t.pendingLock.Lock()
+		delete(t.pending, msgID)
+		t.pendingLock.Unlock()
+	}()
+
+	t.deliverTo(targets, sessionID, msgID, msgBytes, broadcast)
+
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ps.done:
+			return nil
+		case <-time.After(t.deadline):
+		}
+
+		ps.lock.Lock()
+		remaining := make([]uint16, 0, len(ps.need))
+		for id := range ps.need {
+			remaining = append(remaining, id)
+		}
+		ps.lock.Unlock()
+
+		if len(remaining) == 0 {
+			return nil
+		}
+		if attempt >= t.maxRetries {
+			return newTransportAbortError(remaining, fmt.Sprintf("no ack after %d attempts", attempt+1))
+		}
+		t.deliverTo(remaining, sessionID, msgID, msgBytes, broadcast)
+	}
+}
+
+func (t *ChannelTransport) deliverTo(ids []uint16, sessionID SessionID, msgID string, msgBytes []byte, broadcast bool) {
+	for _, id := range ids {
+		if peer := t.peer(id); peer != nil {
+			peer.Deliver(sessionID, msgID, msgBytes, t.id, broadcast)
+		}
+	}
+}
+
+// Deliver queues msgBytes on the bounded channel for (from, sessionID),
+// starting that pair's draining goroutine if this is its first message.
+// The enqueue itself is bounded by this Transport's deadline: if the
+// session's drain goroutine is stalled and its inbox stays full that long,
+// Deliver gives up rather than hanging the caller's Send indefinitely, so a
+// silent peer surfaces as a named culprit instead of deadlocking.
+func (t *ChannelTransport) Deliver(sessionID SessionID, msgID string, msgBytes []byte, from uint16, broadcast bool) {
+	entry := t.inbox(from, sessionID)
+	select {
+	case entry.ch <- inboundMessage{sessionID: sessionID, msgID: msgID, msgBytes: msgBytes, broadcast: broadcast}:
+	case <-entry.done:
+	case <-time.After(t.deadline):
+	}
+}
+
+func (t *ChannelTransport) Ack(sessionID SessionID, msgID string, from uint16) {
+	t.pendingLock.Lock()
+	ps := t.pending[msgID]
+	t.pendingLock.Unlock()
+	if ps == nil {
+		return
+	}
+
+	ps.lock.Lock()
+	delete(ps.need, from)
+	empty := len(ps.need) == 0
+	ps.lock.Unlock()
+
+	if empty {
+		select {
+		case <-ps.done:
+		default:
+			close(ps.done)
+		}
+	}
+}
+
+// inbox returns the bounded channel this Transport drains messages from
+// peer, for sessionID, on, starting that pair's draining goroutine the
+// first time it is seen. Keying per session (rather than per peer alone)
+// means one session's stalled or backed-up messages from a peer never
+// head-of-line-block another session's messages from that same peer.
+func (t *ChannelTransport) inbox(from uint16, sessionID SessionID) *inboxEntry {
+	t.inboxesLock.Lock()
+	defer t.inboxesLock.Unlock()
+
+	key := inboxKey{from: from, sessionID: sessionID}
+	if entry, ok := t.inboxes[key]; ok {
+		return entry
+	}
+
+	entry := &inboxEntry{ch: make(chan inboundMessage, t.inboxSize), done: make(chan struct{})}
+	t.inboxes[key] = entry
+	go t.drain(from, entry)
+	return entry
+}
+
+func (t *ChannelTransport) drain(from uint16, entry *inboxEntry) {
+	for {
+		select {
+		case msg := <-entry.ch:
+			t.onMsg(msg.sessionID, msg.msgBytes, from, msg.broadcast)
+			if peer := t.peer(from); peer != nil {
+				peer.Ack(msg.sessionID, msg.msgID, t.id)
+			}
+		case <-entry.done:
+			return
+		}
+	}
+}
+
+// CloseSession stops the draining goroutine and drops the inbox entry for
+// every peer this Transport holds state for under sessionID, so a
+// long-lived Transport's per-session bookkeeping doesn't grow forever
+// across many signing sessions. A message that arrives for sessionID after
+// CloseSession (e.g. a very late retry from a peer that took longer to
+// finish) simply opens a fresh entry via inbox, same as a session's first
+// message would; callers close a session once they are done with it, not
+// once every peer is guaranteed to be.
+func (t *ChannelTransport) CloseSession(sessionID SessionID) {
+	t.inboxesLock.Lock()
+	defer t.inboxesLock.Unlock()
+
+	for key, entry := range t.inboxes {
+		if key.sessionID != sessionID {
+			continue
+		}
+		close(entry.done)
+		delete(t.inboxes, key)
+	}
+}
+
+var transportMsgSeq uint64
+
+func newTransportMsgID() string {
+	return fmt.Sprintf("%d", atomic.AddUint64(&transportMsgSeq, 1))
+}